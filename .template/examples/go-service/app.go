@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// defaultShutdownTimeout is used when SHUTDOWN_TIMEOUT isn't set or isn't
+// a valid duration.
+const defaultShutdownTimeout = 10 * time.Second
+
+// App wires together routes and middleware into a single http.Handler,
+// and knows how to serve it with graceful shutdown. It's meant to be a
+// starting point: call RegisterRoute/RegisterMiddleware to extend it.
+type App struct {
+	mux         *http.ServeMux
+	middlewares []func(http.Handler) http.Handler
+}
+
+// NewApp returns an empty App ready for routes and middleware.
+func NewApp() *App {
+	return &App{mux: http.NewServeMux()}
+}
+
+// RegisterRoute registers handler for method requests to pattern. Calling
+// it twice with the same pattern and different methods (e.g. GET and
+// POST on "/echo") is how multi-method endpoints are wired.
+func (a *App) RegisterRoute(method, pattern string, handler http.Handler) {
+	a.mux.Handle(method+" "+pattern, handler)
+}
+
+// RegisterMiddleware appends mw to the chain wrapped around every route.
+// Middleware registered first runs outermost, closest to the listener.
+func (a *App) RegisterMiddleware(mw func(http.Handler) http.Handler) {
+	a.middlewares = append(a.middlewares, mw)
+}
+
+// Handler returns the fully wrapped http.Handler for the registered
+// routes and middleware.
+func (a *App) Handler() http.Handler {
+	var h http.Handler = a.mux
+	for i := len(a.middlewares) - 1; i >= 0; i-- {
+		h = a.middlewares[i](h)
+	}
+	return h
+}
+
+// Run listens on addr and serves until a SIGINT or SIGTERM is received,
+// then shuts down gracefully within shutdownTimeout().
+func (a *App) Run(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	return a.Serve(l, sigCh)
+}
+
+// Serve serves a.Handler() on l until sigCh receives a value, then shuts
+// down gracefully within shutdownTimeout(). It's split out from Run so
+// tests can trigger shutdown without sending a real OS signal.
+func (a *App) Serve(l net.Listener, sigCh <-chan os.Signal) error {
+	srv := &http.Server{
+		Handler:      a.Handler(),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-sigCh:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+// shutdownTimeout reads SHUTDOWN_TIMEOUT as a time.Duration, falling
+// back to defaultShutdownTimeout when unset or invalid.
+func shutdownTimeout() time.Duration {
+	v := os.Getenv("SHUTDOWN_TIMEOUT")
+	if v == "" {
+		return defaultShutdownTimeout
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultShutdownTimeout
+	}
+	return d
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppRegisterRouteDispatchesByMethod(t *testing.T) {
+	app := NewApp()
+	app.RegisterRoute("GET", "/widgets", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	app.RegisterRoute("POST", "/widgets", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	srv := app.Handler()
+
+	for _, tt := range []struct {
+		method string
+		want   int
+	}{
+		{http.MethodGet, http.StatusOK},
+		{http.MethodPost, http.StatusCreated},
+		{http.MethodDelete, http.StatusMethodNotAllowed},
+	} {
+		req, err := http.NewRequest(tt.method, "/widgets", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := newRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.status != tt.want {
+			t.Errorf("%s /widgets = %d, want %d", tt.method, rec.status, tt.want)
+		}
+	}
+}
+
+func TestAppMiddlewareRunsInRegistrationOrder(t *testing.T) {
+	var order []string
+	app := NewApp()
+	app.RegisterMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "outer")
+			next.ServeHTTP(w, r)
+		})
+	})
+	app.RegisterMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "inner")
+			next.ServeHTTP(w, r)
+		})
+	})
+	app.RegisterRoute("GET", "/widgets", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/widgets", nil)
+	app.Handler().ServeHTTP(newRecorder(), req)
+
+	want := []string{"outer", "inner", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestAppServeGracefulShutdown(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	app := NewApp()
+	app.RegisterRoute("GET", "/slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	serveErrCh := make(chan error, 1)
+	go func() {
+		serveErrCh <- app.Serve(l, sigCh)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var reqErr error
+	go func() {
+		defer wg.Done()
+		resp, err := http.Get(fmt.Sprintf("http://%s/slow", l.Addr().String()))
+		if err != nil {
+			reqErr = err
+			return
+		}
+		resp.Body.Close()
+	}()
+
+	<-started
+	// Trigger shutdown while the /slow request is still in flight.
+	sigCh <- os.Interrupt
+
+	// The in-flight request must complete before Serve returns.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if reqErr != nil {
+		t.Fatalf("in-flight request failed: %v", reqErr)
+	}
+	if err := <-serveErrCh; err != nil {
+		t.Fatalf("Serve returned error: %v", err)
+	}
+}
+
+// statusOnlyRecorder is a minimal http.ResponseWriter for tests that only need the
+// status code.
+type statusOnlyRecorder struct {
+	header http.Header
+	status int
+}
+
+func newRecorder() *statusOnlyRecorder {
+	return &statusOnlyRecorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (r *statusOnlyRecorder) Header() http.Header         { return r.header }
+func (r *statusOnlyRecorder) Write(b []byte) (int, error) { return len(b), nil }
+func (r *statusOnlyRecorder) WriteHeader(status int)      { r.status = status }
@@ -0,0 +1,270 @@
+// Package client provides a typed HTTP client for the go-service example,
+// mirroring its handlers so integrators don't have to hand-roll requests.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+// HealthResponse mirrors the server's /livez HealthResponse.
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+}
+
+// CheckResult mirrors the server's health.CheckResult.
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadyzResponse mirrors the server's /readyz ReadyzResponse.
+type ReadyzResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// EchoResponse mirrors the server's EchoResponse.
+type EchoResponse struct {
+	Message string `json:"message"`
+	Method  string `json:"method"`
+}
+
+// RequestDecorator mutates an outgoing request before it is sent, e.g. to
+// add an Authorization header or tracing metadata.
+type RequestDecorator func(req *http.Request) error
+
+// Client is a typed wrapper around net/http for calling the go-service
+// example endpoints.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	decorators []RequestDecorator
+	maxRetries int
+	retryBase  time.Duration
+	timeout    *time.Duration
+}
+
+// Option customizes a Client created by New.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used to send requests. The
+// default is a dedicated *http.Client private to this Client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithTimeout sets a default timeout applied to the underlying
+// http.Client. It is applied in New after every Option has run, so it
+// takes effect regardless of ordering relative to WithHTTPClient.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = &d }
+}
+
+// WithRequestDecorator registers a RequestDecorator to run on every
+// outgoing request, in the order given.
+func WithRequestDecorator(d RequestDecorator) Option {
+	return func(c *Client) { c.decorators = append(c.decorators, d) }
+}
+
+// WithRetries sets the maximum number of retries and the base delay for
+// exponential backoff between them. The default is 2 retries at 100ms.
+func WithRetries(maxRetries int, base time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBase = base
+	}
+}
+
+// New returns a Client that talks to baseURL.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+		maxRetries: 2,
+		retryBase:  100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeout != nil {
+		c.httpClient.Timeout = *c.timeout
+	}
+	return c
+}
+
+// Get issues a GET request to path and decodes the JSON response into out.
+func (c *Client) Get(ctx context.Context, path string, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Post issues a POST request to path with payload marshaled as JSON, and
+// decodes the JSON response into out.
+func (c *Client) Post(ctx context.Context, path string, payload, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, payload, out)
+}
+
+// Put issues a PUT request to path with payload marshaled as JSON, and
+// decodes the JSON response into out.
+func (c *Client) Put(ctx context.Context, path string, payload, out interface{}) error {
+	return c.do(ctx, http.MethodPut, path, payload, out)
+}
+
+// Delete issues a DELETE request to path and decodes the JSON response
+// into out.
+func (c *Client) Delete(ctx context.Context, path string, out interface{}) error {
+	return c.do(ctx, http.MethodDelete, path, nil, out)
+}
+
+// Livez calls GET /livez and returns the decoded response.
+func (c *Client) Livez(ctx context.Context) (*HealthResponse, error) {
+	var resp HealthResponse
+	if err := c.Get(ctx, "/livez", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Readyz calls GET /readyz and returns the decoded response. Pass
+// verbose to request the per-check breakdown via ?verbose=1.
+func (c *Client) Readyz(ctx context.Context, verbose bool) (*ReadyzResponse, error) {
+	path := "/readyz"
+	if verbose {
+		path += "?verbose=1"
+	}
+	var resp ReadyzResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		// The server answers 503 with a ReadyzResponse body when it isn't
+		// ready; decode that body instead of returning the status error.
+		var respErr *ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusServiceUnavailable {
+			if jsonErr := json.Unmarshal([]byte(respErr.Body), &resp); jsonErr == nil {
+				return &resp, nil
+			}
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Echo calls POST /echo with msg as the body and returns the decoded
+// response.
+func (c *Client) Echo(ctx context.Context, msg string) (*EchoResponse, error) {
+	var resp EchoResponse
+	if err := c.do(ctx, http.MethodPost, "/echo", rawBody(msg), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// rawBody marks a payload that should be sent as-is rather than
+// marshaled as JSON, used by Echo since /echo takes a raw text body.
+type rawBody string
+
+// ResponseError is returned when the server responds with a non-2xx
+// status code.
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("client: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// isRetryableStatus reports whether status is worth retrying: 5xx
+// indicates a transient server-side failure, and 429 asks the client to
+// back off and try again. Every other 4xx is a permanent rejection of
+// this exact request, so retrying it would just reproduce the same
+// error while risking duplicate side effects on non-idempotent methods.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload, out interface{}) error {
+	var bodyBytes []byte
+	contentType := "application/json"
+
+	switch p := payload.(type) {
+	case nil:
+	case rawBody:
+		bodyBytes = []byte(p)
+		contentType = "text/plain; charset=utf-8"
+	default:
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("client: marshal request: %w", err)
+		}
+		bodyBytes = b
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.retryBase * time.Duration(math.Pow(2, float64(attempt-1)))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return fmt.Errorf("client: build request: %w", err)
+		}
+		if len(bodyBytes) > 0 {
+			req.Header.Set("Content-Type", contentType)
+		}
+		for _, decorate := range c.decorators {
+			if err := decorate(req); err != nil {
+				return fmt.Errorf("client: decorate request: %w", err)
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("client: read response: %w", err)
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			respErr := &ResponseError{StatusCode: resp.StatusCode, Body: string(respBody)}
+			if !isRetryableStatus(resp.StatusCode) {
+				return respErr
+			}
+			lastErr = respErr
+			continue
+		}
+
+		if out == nil {
+			return nil
+		}
+		// A malformed body is a permanent failure, not a transient one;
+		// retrying it would just reproduce the same error.
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("client: decode response: %w", err)
+		}
+		return nil
+	}
+
+	return lastErr
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTimeoutDoesNotMutateDefaultClient(t *testing.T) {
+	before := http.DefaultClient.Timeout
+	New("http://example.invalid", WithTimeout(3*time.Second))
+	if http.DefaultClient.Timeout != before {
+		t.Errorf("http.DefaultClient.Timeout = %v, want unchanged %v", http.DefaultClient.Timeout, before)
+	}
+}
+
+func TestWithTimeoutAppliesRegardlessOfOptionOrder(t *testing.T) {
+	custom := &http.Client{}
+	c := New("http://example.invalid", WithTimeout(3*time.Second), WithHTTPClient(custom))
+	if custom.Timeout != 3*time.Second {
+		t.Errorf("custom.Timeout = %v, want 3s", custom.Timeout)
+	}
+	if c.httpClient != custom {
+		t.Errorf("httpClient = %p, want the client passed to WithHTTPClient", c.httpClient)
+	}
+
+	custom2 := &http.Client{}
+	c2 := New("http://example.invalid", WithHTTPClient(custom2), WithTimeout(3*time.Second))
+	if custom2.Timeout != 3*time.Second {
+		t.Errorf("custom2.Timeout = %v, want 3s", custom2.Timeout)
+	}
+	if c2.httpClient != custom2 {
+		t.Errorf("httpClient = %p, want the client passed to WithHTTPClient", c2.httpClient)
+	}
+}
+
+func TestClientLivez(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","version":"1.0.0"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	resp, err := c.Livez(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != "ok" {
+		t.Errorf("status = %q, want ok", resp.Status)
+	}
+}
+
+func TestClientReadyz(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("verbose"); got != "1" {
+			t.Errorf("verbose query param = %q, want 1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"degraded","checks":{"db":{"status":"fail","latencyMs":5,"error":"timeout"}}}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	resp, err := c.Readyz(context.Background(), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != "degraded" {
+		t.Errorf("status = %q, want degraded", resp.Status)
+	}
+	if resp.Checks["db"].Status != "fail" {
+		t.Errorf("checks[db].Status = %q, want fail", resp.Checks["db"].Status)
+	}
+}
+
+func TestClientEcho(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"hi","method":"POST"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	resp, err := c.Echo(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Message != "hi" {
+		t.Errorf("message = %q, want hi", resp.Message)
+	}
+}
+
+func TestClientNon2xxReturnsResponseError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	_, err := c.Livez(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	respErr, ok := err.(*ResponseError)
+	if !ok {
+		t.Fatalf("error = %T, want *ResponseError", err)
+	}
+	if respErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", respErr.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "nope", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRetries(2, time.Millisecond))
+	if _, err := c.Livez(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (4xx should not be retried)", attempts)
+	}
+}
+
+func TestClientMalformedJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`not json`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRetries(0, time.Millisecond))
+	if _, err := c.Livez(context.Background()); err == nil {
+		t.Fatal("expected a decode error")
+	}
+}
+
+func TestClientNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close() // closed immediately so requests fail to connect
+
+	c := New(srv.URL, WithRetries(1, time.Millisecond))
+	if _, err := c.Livez(context.Background()); err == nil {
+		t.Fatal("expected a network error")
+	}
+}
+
+func TestClientRequestDecorator(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRequestDecorator(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer token")
+		return nil
+	}))
+	if _, err := c.Livez(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer token")
+	}
+}
+
+func TestClientRetriesThenSucceeds(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRetries(2, time.Millisecond))
+	if _, err := c.Livez(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
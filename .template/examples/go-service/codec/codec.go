@@ -0,0 +1,192 @@
+// Package codec implements a small Content-Type aware registry of
+// encoders/decoders, so handlers can negotiate wire format (JSON, XML,
+// form, protobuf, ...) without hard-coding encoding/json calls.
+package codec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Codec decodes a request body into v and encodes a response from v.
+type Codec interface {
+	Decode(r io.Reader, v interface{}) error
+	Encode(w io.Writer, v interface{}) error
+}
+
+// JSON is the Codec ForAccept falls back to when the Accept header is
+// empty or accepts any type.
+var JSON Codec = jsonCodec{}
+
+var registry = map[string]Codec{
+	"application/json":                  jsonCodec{},
+	"application/xml":                   xmlCodec{},
+	"text/xml":                          xmlCodec{},
+	"application/x-www-form-urlencoded": formCodec{},
+	"application/protobuf":              protobufCodec{},
+}
+
+// Register adds or replaces the Codec used for mimeType. Callers
+// typically do this from an init() func in a handler's package.
+func Register(mimeType string, c Codec) {
+	registry[mimeType] = c
+}
+
+// Lookup returns the Codec registered for a raw Content-Type or Accept
+// header value (parameters such as charset are ignored), and the bare
+// mime type it resolved to. ok is false when the header named a type
+// with no registered Codec.
+func Lookup(header string) (c Codec, mimeType string, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil || mediaType == "" {
+		mediaType = strings.TrimSpace(header)
+	}
+	c, ok = registry[mediaType]
+	return c, mediaType, ok
+}
+
+// ForAccept resolves the Codec to encode a response with, given an
+// Accept header. The header may list several types; the first one with a
+// registered Codec wins, and q-values are not considered. An empty header,
+// or a "*/*" entry reached before any registered type, falls back to JSON.
+// ok is false only when the client named specific, unsupported types.
+func ForAccept(header string) (c Codec, mimeType string, ok bool) {
+	if strings.TrimSpace(header) == "" {
+		return JSON, "application/json", true
+	}
+	for _, part := range strings.Split(header, ",") {
+		if c, mimeType, ok = Lookup(part); ok {
+			return c, mimeType, true
+		}
+		if mimeType == "*/*" {
+			return JSON, "application/json", true
+		}
+	}
+	return nil, mimeType, false
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(r io.Reader, v interface{}) error { return json.NewDecoder(r).Decode(v) }
+func (jsonCodec) Encode(w io.Writer, v interface{}) error { return json.NewEncoder(w).Encode(v) }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Decode(r io.Reader, v interface{}) error { return xml.NewDecoder(r).Decode(v) }
+func (xmlCodec) Encode(w io.Writer, v interface{}) error { return xml.NewEncoder(w).Encode(v) }
+
+// formCodec (de)serializes application/x-www-form-urlencoded bodies into
+// the exported string fields of a struct, matched by a `form:"..."` tag
+// or the lowercased field name.
+type formCodec struct{}
+
+func (formCodec) Decode(r io.Reader, v interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("codec: read form body: %w", err)
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return fmt.Errorf("codec: parse form body: %w", err)
+	}
+	return setFields(v, values)
+}
+
+func (formCodec) Encode(w io.Writer, v interface{}) error {
+	values, err := getFields(v)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, values.Encode())
+	return err
+}
+
+// protobufCodec delegates to the Marshal/Unmarshal methods generated
+// protobuf message types carry, so this package doesn't need to depend
+// on a specific protobuf runtime.
+type protobufCodec struct{}
+
+type protoMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type protoUnmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+func (protobufCodec) Decode(r io.Reader, v interface{}) error {
+	u, ok := v.(protoUnmarshaler)
+	if !ok {
+		return fmt.Errorf("codec: %T does not implement Unmarshal([]byte) error", v)
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("codec: read protobuf body: %w", err)
+	}
+	return u.Unmarshal(body)
+}
+
+func (protobufCodec) Encode(w io.Writer, v interface{}) error {
+	m, ok := v.(protoMarshaler)
+	if !ok {
+		return fmt.Errorf("codec: %T does not implement Marshal() ([]byte, error)", v)
+	}
+	body, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func formFieldName(f reflect.StructField) string {
+	if tag := f.Tag.Get("form"); tag != "" {
+		return tag
+	}
+	return strings.ToLower(f.Name)
+}
+
+func setFields(v interface{}, values url.Values) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("codec: form decode target must be a pointer to struct, got %T", v)
+	}
+	elem := rv.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Type.Kind() != reflect.String {
+			continue
+		}
+		if value := values.Get(formFieldName(field)); value != "" {
+			elem.Field(i).SetString(value)
+		}
+	}
+	return nil
+}
+
+func getFields(v interface{}) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("codec: form encode target must be a struct, got %T", v)
+	}
+	t := rv.Type()
+	values := url.Values{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Type.Kind() != reflect.String {
+			continue
+		}
+		values.Set(formFieldName(field), rv.Field(i).String())
+	}
+	return values, nil
+}
@@ -0,0 +1,118 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+type testPayload struct {
+	XMLName xml.Name `xml:"echo" json:"-"`
+	Message string   `json:"message" xml:"message" form:"message"`
+	Method  string   `json:"method" xml:"method" form:"method"`
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	in := testPayload{Message: "hi", Method: "POST"}
+	if err := JSON.Encode(&buf, in); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var out testPayload
+	if err := JSON.Decode(&buf, &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out != in {
+		t.Errorf("got %+v, want %+v", out, in)
+	}
+}
+
+func TestXMLCodecRoundTrip(t *testing.T) {
+	c, _, ok := Lookup("application/xml")
+	if !ok {
+		t.Fatal("expected application/xml to be registered")
+	}
+
+	var buf bytes.Buffer
+	in := testPayload{Message: "hi", Method: "POST"}
+	if err := c.Encode(&buf, in); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var out testPayload
+	if err := c.Decode(&buf, &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	// xml.Unmarshal populates XMLName from the element it read, which
+	// in has no reason to set up front; only Message/Method round-trip.
+	out.XMLName = xml.Name{}
+	if out != in {
+		t.Errorf("got %+v, want %+v", out, in)
+	}
+}
+
+func TestTextXMLSharesXMLCodec(t *testing.T) {
+	c1, mt1, ok1 := Lookup("text/xml")
+	c2, mt2, ok2 := Lookup("application/xml; charset=utf-8")
+	if !ok1 || !ok2 {
+		t.Fatal("expected both text/xml and application/xml to be registered")
+	}
+	if mt1 != "text/xml" || mt2 != "application/xml" {
+		t.Errorf("mime types = %q, %q", mt1, mt2)
+	}
+	if c1 != c2 {
+		t.Error("expected text/xml and application/xml to share a codec implementation")
+	}
+}
+
+func TestFormCodecRoundTrip(t *testing.T) {
+	c, _, ok := Lookup("application/x-www-form-urlencoded")
+	if !ok {
+		t.Fatal("expected form codec to be registered")
+	}
+
+	in := testPayload{Message: "hi there", Method: "POST"}
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, in); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	var out testPayload
+	if err := c.Decode(strings.NewReader(buf.String()), &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.Message != in.Message || out.Method != in.Method {
+		t.Errorf("got %+v, want %+v", out, in)
+	}
+}
+
+func TestForAcceptFallsBackToJSON(t *testing.T) {
+	c, mimeType, ok := ForAccept("")
+	if !ok || mimeType != "application/json" || c != JSON {
+		t.Errorf("ForAccept(\"\") = %v, %q, %v", c, mimeType, ok)
+	}
+
+	c, mimeType, ok = ForAccept("*/*")
+	if !ok || mimeType != "application/json" || c != JSON {
+		t.Errorf(`ForAccept("*/*") = %v, %q, %v`, c, mimeType, ok)
+	}
+}
+
+func TestForAcceptUnsupportedType(t *testing.T) {
+	_, _, ok := ForAccept("application/vnd.unknown+json")
+	if ok {
+		t.Error("expected an unsupported Accept type to fail lookup")
+	}
+}
+
+func TestProtobufCodecRequiresMarshalMethods(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (protobufCodec{}).Encode(&buf, testPayload{}); err == nil {
+		t.Error("expected an error encoding a type without Marshal()")
+	}
+	if err := (protobufCodec{}).Decode(strings.NewReader(""), &testPayload{}); err == nil {
+		t.Error("expected an error decoding into a type without Unmarshal()")
+	}
+}
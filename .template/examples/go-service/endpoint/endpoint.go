@@ -0,0 +1,24 @@
+// Package endpoint provides the Middleware type used to wrap endpoints
+// with cross-cutting concerns such as logging, auth, or request IDs,
+// without those concerns depending on the transport package.
+package endpoint
+
+import "context"
+
+// Endpoint mirrors transport.Endpoint so middleware packages don't need
+// to import transport just to reference the type.
+type Endpoint func(ctx context.Context, request interface{}) (interface{}, error)
+
+// Middleware wraps an Endpoint with additional behavior.
+type Middleware func(Endpoint) Endpoint
+
+// Chain composes middlewares into a single Middleware that applies them
+// in the order given, so Chain(a, b, c)(e) behaves as a(b(c(e))).
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Endpoint) Endpoint {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
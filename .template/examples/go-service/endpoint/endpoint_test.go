@@ -0,0 +1,39 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+)
+
+func TestChainOrdering(t *testing.T) {
+	var order []string
+
+	mark := func(name string) Middleware {
+		return func(next Endpoint) Endpoint {
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				order = append(order, name)
+				return next(ctx, request)
+			}
+		}
+	}
+
+	base := func(ctx context.Context, request interface{}) (interface{}, error) {
+		order = append(order, "base")
+		return nil, nil
+	}
+
+	chained := Chain(mark("a"), mark("b"), mark("c"))(base)
+	if _, err := chained(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a", "b", "c", "base"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
@@ -1,74 +1,283 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"time"
+
+	"ai-template/examples/go-service/codec"
+	"ai-template/examples/go-service/endpoint"
+	"ai-template/examples/go-service/health"
+	"ai-template/examples/go-service/transport"
 )
 
-// HealthResponse represents the health check response.
+// readyzTimeout bounds how long /readyz waits for all registered checks.
+const readyzTimeout = 2 * time.Second
+
+// HealthResponse represents the /livez response: the process is running
+// and built from a known version.
 type HealthResponse struct {
-	Status  string `json:"status"`
-	Version string `json:"version"`
+	Status    string `json:"status"`
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+}
+
+// ReadyzResponse represents the /readyz response: the overall status plus,
+// when requested with ?verbose=1, a per-check breakdown.
+type ReadyzResponse struct {
+	Status string                        `json:"status"`
+	Checks map[string]health.CheckResult `json:"checks,omitempty"`
+}
+
+// readyzRequest is the decoded input to makeReadyzEndpoint.
+type readyzRequest struct {
+	Verbose bool
 }
 
 // EchoResponse represents the echo response.
 type EchoResponse struct {
-	Message string `json:"message"`
-	Method  string `json:"method"`
+	XMLName xml.Name `xml:"echo" json:"-"`
+	Message string   `json:"message" xml:"message" form:"message"`
+	Method  string   `json:"method" xml:"method" form:"method"`
 }
 
-// handleHealth responds with service health status.
-func handleHealth(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+// echoRequest is the decoded input to makeEchoEndpoint.
+type echoRequest struct {
+	Message string
+	Method  string
+}
+
+// echoPayload is the wire shape decoded from a POST body via the codec
+// registry; the HTTP method itself isn't part of the payload.
+type echoPayload struct {
+	XMLName xml.Name `xml:"echo" json:"-"`
+	Message string   `json:"message" xml:"message" form:"message"`
+}
+
+// httpError pairs an HTTP status code with a message so encodeError can
+// map it straight onto the response.
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e httpError) Error() string { return e.message }
+
+// errMethodNotAllowed is returned by decoders when the HTTP method isn't
+// supported by the endpoint.
+var errMethodNotAllowed = httpError{http.StatusMethodNotAllowed, "Method not allowed"}
+
+// errUnsupportedMediaType is returned when a client explicitly names a
+// Content-Type or Accept type with no registered codec.
+var errUnsupportedMediaType = httpError{http.StatusUnsupportedMediaType, "Unsupported Media Type"}
+
+// acceptHeaderKey is the context key used to carry the Accept header from
+// decodeEchoRequest's sibling RequestFunc through to encodeEchoResponse.
+type acceptHeaderKey struct{}
+
+// withAcceptHeader stashes the Accept header in ctx; registered as a
+// transport.ServerBefore hook so the encoder can see it later.
+func withAcceptHeader(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, acceptHeaderKey{}, r.Header.Get("Accept"))
+}
+
+// makeLivezEndpoint returns an Endpoint that reports the process is
+// running. It never fails: liveness doesn't depend on any dependency.
+func makeLivezEndpoint() transport.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		return HealthResponse{
+			Status:    "ok",
+			Version:   Version,
+			GitCommit: GitCommit,
+			BuildDate: BuildDate,
+		}, nil
 	}
+}
+
+// makeReadyzEndpoint returns an Endpoint that runs every registered
+// health.Check and reports whether the service is ready for traffic.
+func makeReadyzEndpoint() transport.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(readyzRequest)
+		result := health.Run(ctx, readyzTimeout)
 
-	response := HealthResponse{
-		Status:  "ok",
-		Version: "1.0.0",
+		resp := ReadyzResponse{Status: result.Status}
+		if req.Verbose {
+			resp.Checks = result.Checks
+		}
+		return resp, nil
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+// makeEchoEndpoint returns an Endpoint that echoes back the decoded
+// message, wrapped with logEndpointCalls so every invocation is recorded
+// independently of the HTTP-level access log.
+func makeEchoEndpoint(logger *slog.Logger) transport.Endpoint {
+	base := endpoint.Endpoint(func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(echoRequest)
+		return EchoResponse{Message: req.Message, Method: req.Method}, nil
+	})
+	chained := endpoint.Chain(logEndpointCalls(logger))(base)
+	return transport.Endpoint(chained)
+}
+
+// logEndpointCalls returns an endpoint.Middleware that logs one
+// structured line per endpoint invocation, tagged with the request ID
+// carried in ctx by withRequestID so it can be correlated with the
+// HTTP-level access log line for the same request.
+func logEndpointCalls(logger *slog.Logger) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+			logger.Info("endpoint called",
+				"request_id", requestIDFromContext(ctx),
+				"duration", time.Since(start).String(),
+				"error", err,
+			)
+			return response, err
+		}
+	}
 }
 
-// handleEcho echoes back the message from query param or body.
-func handleEcho(w http.ResponseWriter, r *http.Request) {
-	var message string
+// decodeLivezRequest rejects anything but GET; liveness takes no input.
+func decodeLivezRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	if r.Method != http.MethodGet {
+		return nil, errMethodNotAllowed
+	}
+	return nil, nil
+}
+
+// decodeReadyzRequest rejects anything but GET and reads the ?verbose=1
+// toggle for including per-check detail in the response.
+func decodeReadyzRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	if r.Method != http.MethodGet {
+		return nil, errMethodNotAllowed
+	}
+	return readyzRequest{Verbose: r.URL.Query().Get("verbose") == "1"}, nil
+}
 
+// decodeEchoRequest extracts the message to echo from the query string on
+// GET, or from the request body on POST.
+func decodeEchoRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	switch r.Method {
 	case http.MethodGet:
-		// Get message from query parameter
-		message = r.URL.Query().Get("msg")
+		message := r.URL.Query().Get("msg")
 		if message == "" {
 			message = "Hello, World!"
 		}
+		return echoRequest{Message: message, Method: r.Method}, nil
 
 	case http.MethodPost:
-		// Get message from request body
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, "Failed to read body", http.StatusBadRequest)
-			return
-		}
 		defer r.Body.Close()
-		message = string(body)
+
+		contentType := r.Header.Get("Content-Type")
+		_, mediaType, _ := codec.Lookup(contentType)
+		if mediaType == "multipart/form-data" {
+			if err := r.ParseMultipartForm(32 << 20); err != nil {
+				return nil, fmt.Errorf("parse multipart form: %w", err)
+			}
+			message := r.FormValue("message")
+			if message == "" {
+				message = "Empty body"
+			}
+			return echoRequest{Message: message, Method: r.Method}, nil
+		}
+
+		// No explicit Content-Type (or text/plain): keep the original
+		// behavior of treating the body as the raw message.
+		if contentType == "" || mediaType == "text/plain" {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return nil, fmt.Errorf("read body: %w", err)
+			}
+			message := string(body)
+			if message == "" {
+				message = "Empty body"
+			}
+			return echoRequest{Message: message, Method: r.Method}, nil
+		}
+
+		c, _, ok := codec.Lookup(contentType)
+		if !ok {
+			return nil, errUnsupportedMediaType
+		}
+		var payload echoPayload
+		if err := c.Decode(r.Body, &payload); err != nil {
+			return nil, fmt.Errorf("decode body: %w", err)
+		}
+		message := payload.Message
 		if message == "" {
 			message = "Empty body"
 		}
+		return echoRequest{Message: message, Method: r.Method}, nil
 
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+		return nil, errMethodNotAllowed
 	}
+}
 
-	response := EchoResponse{
-		Message: message,
-		Method:  r.Method,
+// encodeError maps an httpError to its status code, or falls back to 500
+// for anything else.
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	status := http.StatusInternalServerError
+	if he, ok := err.(httpError); ok {
+		status = he.status
 	}
+	http.Error(w, err.Error(), status)
+}
 
+// encodeJSONResponse writes response as a JSON body.
+func encodeJSONResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// encodeReadyzResponse writes a ReadyzResponse as JSON, using 503 when
+// the service isn't ready and 200 otherwise.
+func encodeReadyzResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ReadyzResponse)
+	status := http.StatusOK
+	if resp.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// encodeEchoResponse negotiates the response format against the Accept
+// header stashed by withAcceptHeader, falling back to JSON when the
+// client didn't ask for anything specific.
+func encodeEchoResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	accept, _ := ctx.Value(acceptHeaderKey{}).(string)
+	c, mimeType, ok := codec.ForAccept(accept)
+	if !ok {
+		return errUnsupportedMediaType
+	}
+	w.Header().Set("Content-Type", mimeType)
+	return c.Encode(w, response)
+}
+
+// encodeTextResponse writes response as plain text, so callers can swap
+// content negotiation without touching the endpoints above.
+func encodeTextResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	switch r := response.(type) {
+	case HealthResponse:
+		_, err := fmt.Fprintf(w, "%s\n", r.Status)
+		return err
+	case EchoResponse:
+		_, err := fmt.Fprintf(w, "%s\n", r.Message)
+		return err
+	default:
+		_, err := fmt.Fprintf(w, "%v\n", r)
+		return err
+	}
 }
@@ -1,14 +1,53 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+
+	"ai-template/examples/go-service/health"
+	"ai-template/examples/go-service/transport"
 )
 
-func TestHealthHandler(t *testing.T) {
+func newLivezServer() http.Handler {
+	return transport.NewServer(
+		makeLivezEndpoint(),
+		decodeLivezRequest,
+		encodeJSONResponse,
+		transport.ServerErrorHandler(encodeError),
+	)
+}
+
+func newReadyzServer() http.Handler {
+	return transport.NewServer(
+		makeReadyzEndpoint(),
+		decodeReadyzRequest,
+		encodeReadyzResponse,
+		transport.ServerErrorHandler(encodeError),
+	)
+}
+
+func newEchoServer() http.Handler {
+	return transport.NewServer(
+		makeEchoEndpoint(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		decodeEchoRequest,
+		encodeEchoResponse,
+		transport.ServerBefore(withAcceptHeader),
+		transport.ServerErrorHandler(encodeError),
+	)
+}
+
+func TestLivezHandler(t *testing.T) {
 	tests := []struct {
 		name       string
 		method     string
@@ -28,12 +67,13 @@ func TestHealthHandler(t *testing.T) {
 		},
 	}
 
+	srv := newLivezServer()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(tt.method, "/health", nil)
+			req := httptest.NewRequest(tt.method, "/livez", nil)
 			rec := httptest.NewRecorder()
 
-			handleHealth(rec, req)
+			srv.ServeHTTP(rec, req)
 
 			if rec.Code != tt.wantStatus {
 				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
@@ -52,19 +92,17 @@ func TestHealthHandler(t *testing.T) {
 	}
 }
 
-func TestHealthHandlerResponse(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+func TestLivezHandlerResponse(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
 	rec := httptest.NewRecorder()
 
-	handleHealth(rec, req)
+	newLivezServer().ServeHTTP(rec, req)
 
-	// Check content type
 	contentType := rec.Header().Get("Content-Type")
 	if contentType != "application/json" {
 		t.Errorf("Content-Type = %q, want application/json", contentType)
 	}
 
-	// Check response structure
 	var response HealthResponse
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
@@ -78,6 +116,58 @@ func TestHealthHandlerResponse(t *testing.T) {
 	}
 }
 
+func TestReadyzHandler(t *testing.T) {
+	health.Reset()
+	defer health.Reset()
+	health.Register("ok-dep", func(ctx context.Context) error { return nil }, health.Critical)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	newReadyzServer().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var response ReadyzResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "ok" {
+		t.Errorf("status = %q, want ok", response.Status)
+	}
+	if response.Checks != nil {
+		t.Errorf("checks = %+v, want nil without ?verbose=1", response.Checks)
+	}
+}
+
+func TestReadyzHandlerDegradedAndVerbose(t *testing.T) {
+	health.Reset()
+	defer health.Reset()
+	health.Register("db", func(ctx context.Context) error { return errors.New("connection refused") }, health.Critical)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz?verbose=1", nil)
+	rec := httptest.NewRecorder()
+
+	newReadyzServer().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	var response ReadyzResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "degraded" {
+		t.Errorf("status = %q, want degraded", response.Status)
+	}
+	if response.Checks["db"].Status != "fail" {
+		t.Errorf("checks[db].Status = %q, want fail", response.Checks["db"].Status)
+	}
+}
+
 func TestEchoHandler(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -122,6 +212,7 @@ func TestEchoHandler(t *testing.T) {
 		},
 	}
 
+	srv := newEchoServer()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var req *http.Request
@@ -132,7 +223,7 @@ func TestEchoHandler(t *testing.T) {
 			}
 			rec := httptest.NewRecorder()
 
-			handleEcho(rec, req)
+			srv.ServeHTTP(rec, req)
 
 			if rec.Code != tt.wantStatus {
 				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
@@ -153,3 +244,155 @@ func TestEchoHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestEchoHandlerXMLBodyAndAccept(t *testing.T) {
+	srv := newEchoServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`<echo><message>bonjour</message></echo>`))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/xml" {
+		t.Errorf("Content-Type = %q, want application/xml", contentType)
+	}
+
+	var response EchoResponse
+	if err := xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode XML response: %v", err)
+	}
+	if response.Message != "bonjour" {
+		t.Errorf("message = %q, want bonjour", response.Message)
+	}
+}
+
+func TestEchoHandlerFormBody(t *testing.T) {
+	srv := newEchoServer()
+
+	body := url.Values{"message": {"from a form"}}.Encode()
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var response EchoResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Message != "from a form" {
+		t.Errorf("message = %q, want %q", response.Message, "from a form")
+	}
+}
+
+func TestEchoHandlerMultipartBody(t *testing.T) {
+	srv := newEchoServer()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("message", "from a multipart form"); err != nil {
+		t.Fatalf("write field: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var response EchoResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Message != "from a multipart form" {
+		t.Errorf("message = %q, want %q", response.Message, "from a multipart form")
+	}
+}
+
+func TestEchoHandlerProtobufBodyAndAccept(t *testing.T) {
+	srv := newEchoServer()
+
+	body, err := echoPayload{Message: "via protobuf"}.Marshal()
+	if err != nil {
+		t.Fatalf("marshal request body: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/protobuf")
+	req.Header.Set("Accept", "application/protobuf")
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/protobuf" {
+		t.Errorf("Content-Type = %q, want application/protobuf", contentType)
+	}
+
+	var response EchoResponse
+	if err := response.Unmarshal(rec.Body.Bytes()); err != nil {
+		t.Fatalf("failed to decode protobuf response: %v", err)
+	}
+	if response.Message != "via protobuf" {
+		t.Errorf("message = %q, want %q", response.Message, "via protobuf")
+	}
+	if response.Method != http.MethodPost {
+		t.Errorf("method = %q, want %q", response.Method, http.MethodPost)
+	}
+}
+
+func TestEchoHandlerUnsupportedContentType(t *testing.T) {
+	srv := newEchoServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("ignored"))
+	req.Header.Set("Content-Type", "application/vnd.unknown+json")
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestEchoHandlerUnsupportedAccept(t *testing.T) {
+	srv := newEchoServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/echo?msg=hi", nil)
+	req.Header.Set("Accept", "application/vnd.unknown+json")
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestEncodeTextResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := encodeTextResponse(nil, rec, EchoResponse{Message: "hi", Method: http.MethodGet}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/plain; charset=utf-8", contentType)
+	}
+	if got := rec.Body.String(); got != "hi\n" {
+		t.Errorf("body = %q, want %q", got, "hi\n")
+	}
+}
@@ -0,0 +1,132 @@
+// Package health implements a registry of named dependency checks used
+// to answer Kubernetes-style readiness probes.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CheckFunc reports whether a dependency is healthy by returning a
+// non-nil error when it isn't.
+type CheckFunc func(ctx context.Context) error
+
+// Severity controls whether a failing check drags down the overall
+// result.
+type Severity int
+
+const (
+	// Critical checks mark the overall Result "degraded" when they fail.
+	Critical Severity = iota
+	// Informational checks are reported but never change the overall
+	// status.
+	Informational
+)
+
+type registeredCheck struct {
+	fn       CheckFunc
+	severity Severity
+}
+
+var (
+	mu     sync.Mutex
+	checks = map[string]registeredCheck{}
+)
+
+// Register adds a named check to the default registry, replacing any
+// check already registered under name.
+func Register(name string, fn CheckFunc, severity Severity) {
+	mu.Lock()
+	defer mu.Unlock()
+	checks[name] = registeredCheck{fn: fn, severity: severity}
+}
+
+// Reset clears the default registry. It exists for tests that don't want
+// checks to leak between cases.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	checks = map[string]registeredCheck{}
+}
+
+// CheckResult is the outcome of running a single named check.
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Result is the outcome of running every registered check.
+type Result struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"-"`
+}
+
+// Run executes every registered check concurrently, bounded by timeout,
+// and aggregates the results. The overall Status is "degraded" if any
+// Critical check failed or didn't finish within timeout; Informational
+// failures are reported but don't affect it.
+func Run(ctx context.Context, timeout time.Duration) Result {
+	mu.Lock()
+	snapshot := make(map[string]registeredCheck, len(checks))
+	for name, c := range checks {
+		snapshot[name] = c
+	}
+	mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		name     string
+		result   CheckResult
+		degrades bool
+	}
+	outcomes := make(chan outcome, len(snapshot))
+
+	var wg sync.WaitGroup
+	for name, c := range snapshot {
+		wg.Add(1)
+		go func(name string, c registeredCheck) {
+			defer wg.Done()
+			start := time.Now()
+			err := runWithin(ctx, c.fn)
+			cr := CheckResult{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+			degrades := false
+			if err != nil {
+				cr.Status = "fail"
+				cr.Error = err.Error()
+				degrades = c.severity == Critical
+			}
+			outcomes <- outcome{name: name, result: cr, degrades: degrades}
+		}(name, c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	result := Result{Status: "ok", Checks: map[string]CheckResult{}}
+	for o := range outcomes {
+		result.Checks[o.name] = o.result
+		if o.degrades {
+			result.Status = "degraded"
+		}
+	}
+	return result
+}
+
+// runWithin runs fn and returns ctx.Err() if it doesn't finish before ctx
+// is done.
+func runWithin(ctx context.Context, fn CheckFunc) error {
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
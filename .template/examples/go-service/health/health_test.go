@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		register   func()
+		wantStatus string
+	}{
+		{
+			name: "all checks pass",
+			register: func() {
+				Register("a", func(ctx context.Context) error { return nil }, Critical)
+				Register("b", func(ctx context.Context) error { return nil }, Informational)
+			},
+			wantStatus: "ok",
+		},
+		{
+			name: "critical check fails",
+			register: func() {
+				Register("db", func(ctx context.Context) error { return errors.New("connection refused") }, Critical)
+			},
+			wantStatus: "degraded",
+		},
+		{
+			name: "only an informational check fails",
+			register: func() {
+				Register("cache", func(ctx context.Context) error { return errors.New("cache miss") }, Informational)
+			},
+			wantStatus: "ok",
+		},
+		{
+			name: "critical check times out",
+			register: func() {
+				Register("slow", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}, Critical)
+			},
+			wantStatus: "degraded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			tt.register()
+
+			result := Run(context.Background(), 20*time.Millisecond)
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", result.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRunReportsPerCheckDetail(t *testing.T) {
+	Reset()
+	Register("db", func(ctx context.Context) error { return nil }, Critical)
+	Register("cache", func(ctx context.Context) error { return errors.New("boom") }, Informational)
+
+	result := Run(context.Background(), 20*time.Millisecond)
+
+	db, ok := result.Checks["db"]
+	if !ok || db.Status != "ok" {
+		t.Errorf("checks[db] = %+v, want status ok", db)
+	}
+
+	cache, ok := result.Checks["cache"]
+	if !ok || cache.Status != "fail" || cache.Error != "boom" {
+		t.Errorf("checks[cache] = %+v, want status fail with error boom", cache)
+	}
+}
+
+func TestRunPartialFailureKeepsPassingChecksOK(t *testing.T) {
+	Reset()
+	Register("db", func(ctx context.Context) error { return nil }, Critical)
+	Register("queue", func(ctx context.Context) error { return errors.New("unreachable") }, Critical)
+
+	result := Run(context.Background(), 20*time.Millisecond)
+
+	if result.Status != "degraded" {
+		t.Fatalf("status = %q, want degraded", result.Status)
+	}
+	if result.Checks["db"].Status != "ok" {
+		t.Errorf("checks[db].Status = %q, want ok", result.Checks["db"].Status)
+	}
+	if result.Checks["queue"].Status != "fail" {
+		t.Errorf("checks[queue].Status = %q, want fail", result.Checks["queue"].Status)
+	}
+}
@@ -2,27 +2,62 @@
 package main
 
 import (
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
+
+	"ai-template/examples/go-service/transport"
+)
+
+// Version, GitCommit, and BuildDate are populated at build time via
+// -ldflags, e.g.:
+//
+//	go build -ldflags "-X main.Version=$(git describe) -X main.GitCommit=$(git rev-parse HEAD) -X main.BuildDate=$(date -u +%FT%TZ)"
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+	BuildDate = "unknown"
 )
 
 func main() {
-	// Get port from environment or default to 8080
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	// Create router and register handlers
-	mux := http.NewServeMux()
-	mux.HandleFunc("/health", handleHealth)
-	mux.HandleFunc("/echo", handleEcho)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	app := NewApp()
+	app.RegisterMiddleware(withRequestID)
+	app.RegisterMiddleware(withRecovery(logger))
+	app.RegisterMiddleware(withAccessLog(logger))
+
+	app.RegisterRoute("GET", "/livez", transport.NewServer(
+		makeLivezEndpoint(),
+		decodeLivezRequest,
+		encodeJSONResponse,
+		transport.ServerErrorHandler(encodeError),
+	))
+	app.RegisterRoute("GET", "/readyz", transport.NewServer(
+		makeReadyzEndpoint(),
+		decodeReadyzRequest,
+		encodeReadyzResponse,
+		transport.ServerErrorHandler(encodeError),
+	))
+
+	echoServer := transport.NewServer(
+		makeEchoEndpoint(logger),
+		decodeEchoRequest,
+		encodeEchoResponse,
+		transport.ServerBefore(withAcceptHeader),
+		transport.ServerErrorHandler(encodeError),
+	)
+	app.RegisterRoute("GET", "/echo", echoServer)
+	app.RegisterRoute("POST", "/echo", echoServer)
 
-	// Start server
 	addr := ":" + port
-	log.Printf("Starting server on %s", addr)
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("Server failed: %v", err)
+	logger.Info("starting server", "addr", addr)
+	if err := app.Run(addr); err != nil {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
 	}
 }
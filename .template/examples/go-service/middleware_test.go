@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithRequestIDSetsHeaderAndContext(t *testing.T) {
+	var sawID string
+	handler := withRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawID = requestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	headerID := rec.Header().Get("X-Request-Id")
+	if headerID == "" {
+		t.Fatal("expected X-Request-Id header to be set")
+	}
+	if sawID != headerID {
+		t.Errorf("context request ID = %q, want %q", sawID, headerID)
+	}
+}
+
+func TestWithRecoveryCatchesPanics(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	handler := withRecovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(buf.String(), "panic recovered") {
+		t.Errorf("log output = %q, want it to mention the recovered panic", buf.String())
+	}
+}
+
+func TestWithRecoveryChainedAfterRequestIDSeesRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	// Mirrors the registration order in main: withRequestID must run
+	// outermost so withRecovery's panic handler can see the ID it set.
+	handler := withRequestID(withRecovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	headerID := rec.Header().Get("X-Request-Id")
+	if headerID == "" {
+		t.Fatal("expected X-Request-Id header to be set")
+	}
+	if !strings.Contains(buf.String(), "request_id="+headerID) {
+		t.Errorf("log output = %q, want it to contain request_id=%s", buf.String(), headerID)
+	}
+}
+
+func TestWithAccessLogRecordsStatusAndMethod(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	handler := withAccessLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/widgets", nil))
+
+	out := buf.String()
+	if !strings.Contains(out, "status=418") {
+		t.Errorf("log output = %q, want it to record status=418", out)
+	}
+	if !strings.Contains(out, "method=POST") {
+		t.Errorf("log output = %q, want it to record method=POST", out)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import "fmt"
+
+// Marshal/Unmarshal on EchoResponse and echoPayload implement just enough
+// of the protobuf wire format (varint tags, length-delimited strings) for
+// protobufCodec to round-trip them over /echo, without pulling in a full
+// protobuf runtime or generated code. Message is field 1, Method is field
+// 2, matching a hypothetical .proto of:
+//
+//	message Echo {
+//	  string message = 1;
+//	  string method = 2;
+//	}
+
+// Marshal encodes r as a protobuf message with message=1, method=2.
+func (r EchoResponse) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendProtoString(buf, 1, r.Message)
+	buf = appendProtoString(buf, 2, r.Method)
+	return buf, nil
+}
+
+// Unmarshal decodes a protobuf message produced by Marshal into r.
+func (r *EchoResponse) Unmarshal(b []byte) error {
+	return parseProtoStrings(b, map[int]*string{1: &r.Message, 2: &r.Method})
+}
+
+// Marshal encodes p as a protobuf message with message=1. echoPayload
+// only ever carries the message field over the wire; Method is derived
+// from the HTTP method, not the body.
+func (p echoPayload) Marshal() ([]byte, error) {
+	return appendProtoString(nil, 1, p.Message), nil
+}
+
+// Unmarshal decodes a protobuf message produced by Marshal into p.
+func (p *echoPayload) Unmarshal(b []byte) error {
+	return parseProtoStrings(b, map[int]*string{1: &p.Message})
+}
+
+// appendProtoString appends a length-delimited string field (wire type 2)
+// tagged with fieldNum to buf, per the protobuf wire format.
+func appendProtoString(buf []byte, fieldNum int, s string) []byte {
+	const wireTypeLengthDelimited = 2
+	buf = appendVarint(buf, uint64(fieldNum)<<3|wireTypeLengthDelimited)
+	buf = appendVarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// appendVarint appends x to buf using protobuf's base-128 varint
+// encoding.
+func appendVarint(buf []byte, x uint64) []byte {
+	for x >= 0x80 {
+		buf = append(buf, byte(x)|0x80)
+		x >>= 7
+	}
+	return append(buf, byte(x))
+}
+
+// parseProtoStrings reads length-delimited string fields out of b,
+// assigning each to the destination named in fields by field number, and
+// skipping any field not present there.
+func parseProtoStrings(b []byte, fields map[int]*string) error {
+	for len(b) > 0 {
+		tag, n, err := readVarint(b)
+		if err != nil {
+			return fmt.Errorf("codec: read field tag: %w", err)
+		}
+		b = b[n:]
+
+		fieldNum := int(tag >> 3)
+		wireType := int(tag & 0x7)
+		if wireType != 2 {
+			return fmt.Errorf("codec: field %d has unsupported wire type %d", fieldNum, wireType)
+		}
+
+		length, n, err := readVarint(b)
+		if err != nil {
+			return fmt.Errorf("codec: read field %d length: %w", fieldNum, err)
+		}
+		b = b[n:]
+
+		if uint64(len(b)) < length {
+			return fmt.Errorf("codec: field %d length %d exceeds remaining body", fieldNum, length)
+		}
+		value := string(b[:length])
+		b = b[length:]
+
+		if dst, ok := fields[fieldNum]; ok {
+			*dst = value
+		}
+	}
+	return nil
+}
+
+// readVarint decodes a base-128 varint from the start of b, returning the
+// value and the number of bytes consumed.
+func readVarint(b []byte) (x uint64, n int, err error) {
+	var shift uint
+	for i, byt := range b {
+		if i == 10 {
+			return 0, 0, fmt.Errorf("varint too long")
+		}
+		x |= uint64(byt&0x7f) << shift
+		if byt < 0x80 {
+			return x, i + 1, nil
+		}
+		shift += 7
+	}
+	return 0, 0, fmt.Errorf("truncated varint")
+}
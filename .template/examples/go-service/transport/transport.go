@@ -0,0 +1,118 @@
+// Package transport implements a small go-kit style request pipeline that
+// decouples endpoints from HTTP transport details: a DecodeRequestFunc
+// turns an *http.Request into a domain request, an Endpoint processes it,
+// and an EncodeResponseFunc writes the result back out.
+package transport
+
+import (
+	"context"
+	"net/http"
+)
+
+// Endpoint is the fundamental building block: it takes a decoded request
+// and returns a response or an error, with no knowledge of HTTP.
+type Endpoint func(ctx context.Context, request interface{}) (interface{}, error)
+
+// DecodeRequestFunc extracts a domain request from an HTTP request.
+type DecodeRequestFunc func(ctx context.Context, r *http.Request) (interface{}, error)
+
+// EncodeResponseFunc encodes the response returned by an Endpoint and
+// writes it to the ResponseWriter.
+type EncodeResponseFunc func(ctx context.Context, w http.ResponseWriter, response interface{}) error
+
+// ErrorEncoder writes err to the ResponseWriter. It is responsible for
+// choosing the status code and body format.
+type ErrorEncoder func(ctx context.Context, err error, w http.ResponseWriter)
+
+// RequestFunc runs before the request is decoded and may enrich the
+// context, e.g. with a request ID.
+type RequestFunc func(ctx context.Context, r *http.Request) context.Context
+
+// ResponseFunc runs after the endpoint returns but before the response is
+// encoded, and may set headers on w.
+type ResponseFunc func(ctx context.Context, w http.ResponseWriter) context.Context
+
+// Server binds an Endpoint to an http.Handler using a DecodeRequestFunc
+// and an EncodeResponseFunc.
+type Server struct {
+	endpoint Endpoint
+	decode   DecodeRequestFunc
+	encode   EncodeResponseFunc
+
+	errorEncoder ErrorEncoder
+	before       []RequestFunc
+	after        []ResponseFunc
+}
+
+// ServerOption customizes a Server created by NewServer.
+type ServerOption func(*Server)
+
+// ServerBefore adds RequestFuncs to run, in order, before the request is
+// decoded.
+func ServerBefore(fns ...RequestFunc) ServerOption {
+	return func(s *Server) { s.before = append(s.before, fns...) }
+}
+
+// ServerAfter adds ResponseFuncs to run, in order, after the endpoint
+// returns but before the response is encoded.
+func ServerAfter(fns ...ResponseFunc) ServerOption {
+	return func(s *Server) { s.after = append(s.after, fns...) }
+}
+
+// ServerErrorHandler sets the ErrorEncoder used when decoding or the
+// endpoint fails. DefaultErrorEncoder is used if this option is omitted.
+func ServerErrorHandler(ee ErrorEncoder) ServerOption {
+	return func(s *Server) { s.errorEncoder = ee }
+}
+
+// NewServer returns an http.Handler that decodes each request, invokes
+// endpoint, and encodes the response.
+func NewServer(endpoint Endpoint, decode DecodeRequestFunc, encode EncodeResponseFunc, opts ...ServerOption) http.Handler {
+	s := &Server{
+		endpoint:     endpoint,
+		decode:       decode,
+		encode:       encode,
+		errorEncoder: DefaultErrorEncoder,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	for _, f := range s.before {
+		ctx = f(ctx, r)
+	}
+
+	request, err := s.decode(ctx, r)
+	if err != nil {
+		s.errorEncoder(ctx, err, w)
+		return
+	}
+
+	response, err := s.endpoint(ctx, request)
+	if err != nil {
+		s.errorEncoder(ctx, err, w)
+		return
+	}
+
+	for _, f := range s.after {
+		ctx = f(ctx, w)
+	}
+
+	if err := s.encode(ctx, w, response); err != nil {
+		s.errorEncoder(ctx, err, w)
+		return
+	}
+}
+
+// DefaultErrorEncoder writes err.Error() as a 500 plain-text response.
+func DefaultErrorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte(err.Error()))
+}
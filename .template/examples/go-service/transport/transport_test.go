@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewServerHappyPath(t *testing.T) {
+	endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return request, nil
+	}
+	decode := func(ctx context.Context, r *http.Request) (interface{}, error) {
+		return "decoded", nil
+	}
+	encode := func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(response.(string)))
+		return err
+	}
+
+	srv := NewServer(endpoint, decode, encode)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != "decoded" {
+		t.Fatalf("body = %q, want %q", rec.Body.String(), "decoded")
+	}
+}
+
+func TestNewServerDecodeError(t *testing.T) {
+	wantErr := errors.New("bad request")
+	decode := func(ctx context.Context, r *http.Request) (interface{}, error) {
+		return nil, wantErr
+	}
+	endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+		t.Fatal("endpoint should not be called when decode fails")
+		return nil, nil
+	}
+	encode := func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		t.Fatal("encode should not be called when decode fails")
+		return nil
+	}
+
+	srv := NewServer(endpoint, decode, encode)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if rec.Body.String() != wantErr.Error() {
+		t.Fatalf("body = %q, want %q", rec.Body.String(), wantErr.Error())
+	}
+}
+
+func TestServerErrorHandlerOption(t *testing.T) {
+	wantErr := errors.New("boom")
+	endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, wantErr
+	}
+	decode := func(ctx context.Context, r *http.Request) (interface{}, error) {
+		return nil, nil
+	}
+	encode := func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		return nil
+	}
+	custom := func(ctx context.Context, err error, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	srv := NewServer(endpoint, decode, encode, ServerErrorHandler(custom))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+}
+
+func TestServerBeforeAndAfter(t *testing.T) {
+	type ctxKey string
+	const key ctxKey = "injected"
+
+	before := func(ctx context.Context, r *http.Request) context.Context {
+		return context.WithValue(ctx, key, "request-id")
+	}
+	after := func(ctx context.Context, w http.ResponseWriter) context.Context {
+		w.Header().Set("X-Request-Id", ctx.Value(key).(string))
+		return ctx
+	}
+	decode := func(ctx context.Context, r *http.Request) (interface{}, error) {
+		return nil, nil
+	}
+	endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	encode := func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		return nil
+	}
+
+	srv := NewServer(endpoint, decode, encode, ServerBefore(before), ServerAfter(after))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := rec.Header().Get("X-Request-Id"); got != "request-id" {
+		t.Fatalf("X-Request-Id = %q, want %q", got, "request-id")
+	}
+}